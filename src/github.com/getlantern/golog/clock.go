@@ -0,0 +1,31 @@
+package golog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// coarseNow is updated roughly every 20ms by a background goroutine so rate
+// limiting can check the time without a time.Now() syscall on every log
+// call, the same trick used by the flowrate package. The goroutine only
+// starts the first time RateLimit is used (see ensureCoarseClock), so
+// merely importing golog doesn't leave a ticker running forever in
+// processes that never rate-limit anything.
+var (
+	coarseNow        int64
+	startCoarseClock sync.Once
+)
+
+// ensureCoarseClock starts the background goroutine that keeps coarseNow
+// up to date, if it hasn't already been started.
+func ensureCoarseClock() {
+	startCoarseClock.Do(func() {
+		atomic.StoreInt64(&coarseNow, time.Now().UnixNano())
+		go func() {
+			for range time.Tick(20 * time.Millisecond) {
+				atomic.StoreInt64(&coarseNow, time.Now().UnixNano())
+			}
+		}()
+	})
+}
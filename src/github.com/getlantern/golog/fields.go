@@ -0,0 +1,66 @@
+package golog
+
+import "context"
+
+// Fields is a set of structured key/value pairs attached to a logger or a
+// single log entry.
+type Fields map[string]interface{}
+
+// WithField returns a child Logger that merges key/val into every subsequent
+// log entry's fields. The receiver is left unmodified.
+func (l *logger) WithField(key string, val interface{}) Logger {
+	return l.withFields(Fields{key: val})
+}
+
+// WithFields returns a child Logger that merges fields into every subsequent
+// log entry's fields. The receiver is left unmodified.
+func (l *logger) WithFields(fields Fields) Logger {
+	return l.withFields(fields)
+}
+
+// WithError returns a child Logger with an "error" field set to err.Error().
+// If err carries a captured stack trace (see CaptureError), it's included in
+// every entry the child Logger subsequently logs. If err is nil, the
+// receiver is returned unchanged.
+func (l *logger) WithError(err error) Logger {
+	if err == nil {
+		return l
+	}
+	child := l.withFields(Fields{"error": err.Error()}).(*logger)
+	if frames := framesOf(err); frames != nil {
+		child.stack = frames
+	}
+	return child
+}
+
+// WithContext returns a child Logger that adopts ctx and, if ctx already
+// carries a Logger of its own (stashed via the package-level WithContext in
+// logctx.go, e.g. by HTTPMiddleware), merges that Logger's fields in too, so
+// request-scoped fields survive a handoff through a context.Context.
+func (l *logger) WithContext(ctx context.Context) Logger {
+	child := l.clone()
+	child.ctx = ctx
+	if stashed, ok := ctx.Value(loggerContextKey).(*logger); ok && stashed != nil {
+		child = child.withFields(stashed.fields).(*logger)
+	}
+	return child
+}
+
+func (l *logger) withFields(fields Fields) Logger {
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	child := l.clone()
+	child.fields = merged
+	return child
+}
+
+// clone returns a shallow copy of l that's safe to mutate independently.
+func (l *logger) clone() *logger {
+	c := *l
+	return &c
+}
@@ -0,0 +1,47 @@
+package golog
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFields(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+	l := LoggerFor("myprefix").WithField("user", "alice").WithFields(Fields{"n": 5})
+	l.Debug("Hello")
+	assert.Regexp(t, `DEBUG myprefix: fields_test.go:\d+ Hello n=5 user=alice`, string(out.Bytes()))
+}
+
+func TestWithError(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(out, ioutil.Discard)
+	l := LoggerFor("myprefix").WithError(errors.New("boom"))
+	l.Error("failed")
+	assert.Regexp(t, `ERROR myprefix: fields_test.go:\d+ failed error=boom`, string(out.Bytes()))
+}
+
+func TestHooks(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+
+	var captured []*Entry
+	AddHook(hookFunc(func(e *Entry) error {
+		captured = append(captured, e)
+		return nil
+	}), Debug)
+
+	l := LoggerFor("myprefix")
+	l.Debug("seen")
+	l.Trace("not seen") // below the default level, and not at Debug anyway
+
+	assert.Len(t, captured, 1)
+	assert.Equal(t, "seen", captured[0].Message)
+}
+
+type hookFunc func(*Entry) error
+
+func (f hookFunc) Fire(e *Entry) error { return f(e) }
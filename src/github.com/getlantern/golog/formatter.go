@@ -0,0 +1,96 @@
+package golog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Entry represents a single log event as seen by Formatters and Hooks.
+type Entry struct {
+	Time     time.Time
+	Level    Level
+	Prefix   string
+	Location string
+	Message  string
+	Fields   Fields
+	// Stack is the stack trace captured for this entry's error, if any (see
+	// CaptureError and Logger.ErrorWithStack).
+	Stack []Frame
+}
+
+// Formatter renders an Entry to the bytes that get written to the configured
+// output.
+type Formatter interface {
+	Format(entry *Entry) ([]byte, error)
+}
+
+var activeFormatter atomic.Value
+
+func init() {
+	SetFormatter(&TextFormatter{})
+}
+
+// SetFormatter sets the Formatter used to render entries before they're
+// written to the outputs configured via SetOutputs.
+func SetFormatter(f Formatter) {
+	activeFormatter.Store(f)
+}
+
+func currentFormatter() Formatter {
+	return activeFormatter.Load().(Formatter)
+}
+
+// TextFormatter renders an Entry the way golog always has:
+// "SEVERITY prefix: file:line message key=value ...". It's the default
+// Formatter.
+type TextFormatter struct{}
+
+// Format implements the Formatter interface.
+func (*TextFormatter) Format(entry *Entry) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s%s %s", entry.Level, entry.Prefix, entry.Location, entry.Message)
+	if len(entry.Fields) > 0 {
+		keys := make([]string, 0, len(entry.Fields))
+		for k := range entry.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, " %s=%v", k, entry.Fields[k])
+		}
+	}
+	b.WriteString("\n")
+	for _, f := range entry.Stack {
+		fmt.Fprintf(&b, "\t%s\n", f)
+	}
+	return []byte(b.String()), nil
+}
+
+// JSONFormatter renders an Entry as a single line of JSON, suitable for
+// ingestion by log aggregators.
+type JSONFormatter struct{}
+
+// Format implements the Formatter interface.
+func (*JSONFormatter) Format(entry *Entry) ([]byte, error) {
+	m := make(map[string]interface{}, len(entry.Fields)+4)
+	for k, v := range entry.Fields {
+		m[k] = v
+	}
+	m["time"] = entry.Time.Format(time.RFC3339Nano)
+	m["level"] = entry.Level.String()
+	m["prefix"] = strings.TrimSuffix(entry.Prefix, ": ")
+	m["location"] = entry.Location
+	m["message"] = entry.Message
+	if len(entry.Stack) > 0 {
+		m["stack"] = entry.Stack
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,324 @@
+// Package golog implements logging functions that log errors to stderr and
+// everything else to stdout.
+package golog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	outs atomic.Value
+)
+
+type outputPair struct {
+	ErrorOut io.Writer
+	DebugOut io.Writer
+}
+
+func init() {
+	SetOutputs(os.Stderr, os.Stdout)
+}
+
+// SetOutputs sets the outputs for a Logger. For efficiency reasons, logging
+// to a Writer that does not synchronize output may result in interlaced
+// output.
+func SetOutputs(errorOut io.Writer, debugOut io.Writer) {
+	outs.Store(&outputPair{errorOut, debugOut})
+}
+
+// Logger is the interface implemented by loggers returned from LoggerFor.
+type Logger interface {
+	// Debug logs a debug message.
+	Debug(arg interface{})
+
+	// Debugf logs a formatted debug message.
+	Debugf(message string, args ...interface{})
+
+	// Error logs an error message and returns an error with that message.
+	// If arg already carries a stack trace (see CaptureError), it's included
+	// in the emitted entry.
+	Error(arg interface{}) error
+
+	// ErrorWithStack logs err as an Error entry with a captured (or, if err
+	// already has one, reused) stack trace, and returns the stack-carrying
+	// error.
+	ErrorWithStack(err error) error
+
+	// Errorf logs a formatted error message and returns an error with that
+	// message.
+	Errorf(message string, args ...interface{}) error
+
+	// Trace logs a trace message if this Logger's Level is Trace (the default
+	// when TRACE=true).
+	Trace(arg interface{})
+
+	// Tracef logs a formatted trace message if this Logger's Level is Trace
+	// (the default when TRACE=true).
+	Tracef(message string, args ...interface{})
+
+	// TraceOut provides access to an io.Writer to which trace information can
+	// be streamed. If this Logger's Level is above Trace when TraceOut is
+	// called, the writer will be a no-op writer.
+	TraceOut() io.Writer
+
+	// AsStdLogger returns a standard library log.Logger that routes through
+	// this Logger's Error output.
+	AsStdLogger() *log.Logger
+
+	// Info logs an info message.
+	Info(arg interface{})
+
+	// Infof logs a formatted info message.
+	Infof(message string, args ...interface{})
+
+	// Warn logs a warning message.
+	Warn(arg interface{})
+
+	// Warnf logs a formatted warning message.
+	Warnf(message string, args ...interface{})
+
+	// WithField returns a child Logger that merges key/val into the fields
+	// of every entry it subsequently logs.
+	WithField(key string, val interface{}) Logger
+
+	// WithFields returns a child Logger that merges fields into the fields
+	// of every entry it subsequently logs.
+	WithFields(fields Fields) Logger
+
+	// WithError returns a child Logger with an "error" field describing err.
+	WithError(err error) Logger
+
+	// WithContext returns a child Logger associated with ctx, merging in
+	// the fields of any Logger already stashed in ctx (see WithRequestID,
+	// WithTraceID, and the package-level WithContext/FromContext).
+	WithContext(ctx context.Context) Logger
+
+	// WithRequestID returns a child Logger with a request_id field attached
+	// to every entry it subsequently logs.
+	WithRequestID(id string) Logger
+
+	// WithTraceID returns a child Logger with a trace_id field attached to
+	// every entry it subsequently logs.
+	WithTraceID(id string) Logger
+
+	// Sample returns a child Logger that, for each distinct call site, only
+	// emits every nth call. The rest are counted and periodically surfaced
+	// as a single "N similar messages suppressed" line.
+	Sample(n int) Logger
+
+	// RateLimit returns a child Logger that, for each distinct call site,
+	// emits at most burst calls every per. Calls beyond the burst are
+	// counted and periodically surfaced as a single "N similar messages
+	// suppressed" line.
+	RateLimit(per time.Duration, burst int) Logger
+}
+
+// LoggerFor creates a new Logger for the given prefix. Its Level defaults to
+// Debug (or Trace, if TRACE=true), but can be changed at any time, for this
+// or any other Logger sharing the prefix, via SetLevel/SetLevelGlob.
+func LoggerFor(prefix string) Logger {
+	return &logger{
+		name:   prefix,
+		prefix: prefix + ": ",
+		level:  registerPrefix(prefix),
+	}
+}
+
+type logger struct {
+	name    string
+	prefix  string
+	level   *int32
+	fields  Fields
+	ctx     context.Context
+	limiter limiter
+	stack   []Frame
+}
+
+func (l *logger) enabled(lv Level) bool {
+	return lv >= effectiveLevel(l.level)
+}
+
+// dispatch is the common path for every severity: it checks the level,
+// resolves the output, applies any Sample/RateLimit configured on l, and
+// hands off to emit. callDepth is how many frames above dispatch itself the
+// user's original call site is: 2 when dispatch is called directly from
+// Debug, Error and the like (see locate). frames, if
+// non-nil, takes precedence over any stack trace carried by l itself (see
+// WithError); it's how Error/Errorf/ErrorWithStack surface a trace attached
+// to the specific error being logged.
+func (l *logger) dispatch(level Level, callDepth int, message string, frames []Frame) {
+	if !l.enabled(level) {
+		return
+	}
+	if frames == nil {
+		frames = l.stack
+	}
+	o := outs.Load().(*outputPair)
+	out := o.DebugOut
+	if level == Error {
+		out = o.ErrorOut
+	}
+	loc, pc := locate(callDepth + 1)
+	if l.limiter != nil {
+		allowed := l.limiter.allow(pc, func(suppressed int) {
+			l.emit(out, level, loc, fmt.Sprintf("%d similar messages suppressed", suppressed), nil)
+		})
+		if !allowed {
+			return
+		}
+	}
+	l.emit(out, level, loc, message, frames)
+}
+
+func (l *logger) Debug(arg interface{}) {
+	l.dispatch(Debug, 2, fmt.Sprint(arg), nil)
+}
+
+func (l *logger) Debugf(message string, args ...interface{}) {
+	l.dispatch(Debug, 2, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *logger) Info(arg interface{}) {
+	l.dispatch(Info, 2, fmt.Sprint(arg), nil)
+}
+
+func (l *logger) Infof(message string, args ...interface{}) {
+	l.dispatch(Info, 2, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *logger) Warn(arg interface{}) {
+	l.dispatch(Warn, 2, fmt.Sprint(arg), nil)
+}
+
+func (l *logger) Warnf(message string, args ...interface{}) {
+	l.dispatch(Warn, 2, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *logger) Error(arg interface{}) error {
+	var err error
+	var frames []Frame
+	if e, ok := arg.(error); ok {
+		err = e
+		frames = framesOf(e)
+	} else {
+		err = fmt.Errorf("%v", arg)
+	}
+	l.dispatch(Error, 2, err.Error(), frames)
+	return err
+}
+
+func (l *logger) Errorf(message string, args ...interface{}) error {
+	err := fmt.Errorf(message, args...)
+	l.dispatch(Error, 2, err.Error(), framesOf(err))
+	return err
+}
+
+func (l *logger) Trace(arg interface{}) {
+	l.dispatch(Trace, 2, fmt.Sprint(arg), nil)
+}
+
+func (l *logger) Tracef(message string, args ...interface{}) {
+	l.dispatch(Trace, 2, fmt.Sprintf(message, args...), nil)
+}
+
+// emit builds an Entry from the given level/location/message/stack plus l's
+// accumulated fields, fires it through the registered hooks, renders it with
+// the active Formatter, and writes the result to out.
+func (l *logger) emit(out io.Writer, level Level, loc string, message string, frames []Frame) {
+	entry := &Entry{
+		Time:     time.Now(),
+		Level:    level,
+		Prefix:   l.prefix,
+		Location: loc,
+		Message:  message,
+		Fields:   l.fields,
+		Stack:    frames,
+	}
+	fireHooks(entry)
+	b, err := currentFormatter().Format(entry)
+	if err != nil {
+		fmt.Fprintf(out, "%s %s%s error formatting entry: %v\n", level, l.prefix, loc, err)
+		return
+	}
+	out.Write(b)
+}
+
+// TraceOut returns a writer that, when written to, logs each line written to
+// it as a Trace message attributed to the call site of TraceOut itself
+// (rather than to whatever goroutine happens to be pumping the pipe).
+func (l *logger) TraceOut() io.Writer {
+	if !l.enabled(Trace) {
+		return ioutil.Discard
+	}
+	loc, pc := locate(2)
+	pr, pw := io.Pipe()
+	go func() {
+		r := bufio.NewReader(pr)
+		for {
+			line, err := r.ReadString('\n')
+			if len(line) > 0 {
+				l.traceLine(loc, pc, strings.TrimRight(line, "\n"))
+			}
+			if err != nil {
+				l.traceLine(loc, pc, fmt.Sprintf("TraceWriter closed due to unexpected error: %v", err))
+				return
+			}
+		}
+	}()
+	return pw
+}
+
+// traceLine emits one line written to a TraceOut writer, honoring whatever
+// Sample/RateLimit was configured on l for the writer's call site.
+func (l *logger) traceLine(loc string, pc uintptr, message string) {
+	o := outs.Load().(*outputPair)
+	if l.limiter != nil {
+		allowed := l.limiter.allow(pc, func(suppressed int) {
+			l.emit(o.DebugOut, Trace, loc, fmt.Sprintf("%d similar messages suppressed", suppressed), nil)
+		})
+		if !allowed {
+			return
+		}
+	}
+	l.emit(o.DebugOut, Trace, loc, message, nil)
+}
+
+func (l *logger) AsStdLogger() *log.Logger {
+	return log.New(&stdLogWriter{l}, "", 0)
+}
+
+// stdLogWriter adapts a logger to the io.Writer expected by log.New, logging
+// everything written to it as an Error at the call site of the standard
+// logger's Print/Printf/Println.
+type stdLogWriter struct {
+	l *logger
+}
+
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\n")
+	w.l.dispatch(Error, 4, msg, nil)
+	return len(p), nil
+}
+
+// locate returns the file:line and program counter of the caller skip
+// frames up from locate itself (as with runtime.Caller, 1 is locate's
+// caller). The PC is used to key
+// per-call-site sampling and rate limiting.
+func locate(skip int) (string, uintptr) {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "?:0", 0
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line), pc
+}
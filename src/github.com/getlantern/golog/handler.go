@@ -0,0 +1,51 @@
+package golog
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LevelHandler returns an http.Handler, suitable for mounting alongside
+// net/http/pprof, that lets operators inspect and adjust log levels on a
+// live process.
+//
+// GET returns the current level of every registered prefix as JSON. PUT
+// accepts a JSON body of the form {"prefix": "myprefix", "level": "debug"}
+// (prefix may be a glob such as "http.*") and applies it via SetLevelGlob.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(levelStrings(Levels())); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		case http.MethodPut:
+			var req struct {
+				Prefix string `json:"prefix"`
+				Level  string `json:"level"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, err := ParseLevel(req.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			SetLevelGlob(req.Prefix, level)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func levelStrings(levels map[string]Level) map[string]string {
+	out := make(map[string]string, len(levels))
+	for prefix, level := range levels {
+		out[prefix] = level.String()
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Hook receives every log Entry fired at one of its registered levels. Hooks
+// are used to ship entries to side channels like Sentry, syslog, or metrics,
+// independently of the configured Formatter and outputs.
+type Hook interface {
+	Fire(entry *Entry) error
+}
+
+type hookRegistration struct {
+	hook   Hook
+	levels map[Level]bool
+}
+
+var (
+	hooksMu sync.Mutex
+	hooks   []hookRegistration
+)
+
+// AddHook registers hook to fire for entries at any of the given levels. If
+// no levels are given, the hook fires for every entry.
+func AddHook(hook Hook, levels ...Level) {
+	reg := hookRegistration{hook: hook}
+	if len(levels) > 0 {
+		reg.levels = make(map[Level]bool, len(levels))
+		for _, lv := range levels {
+			reg.levels[lv] = true
+		}
+	}
+	hooksMu.Lock()
+	hooks = append(hooks, reg)
+	hooksMu.Unlock()
+}
+
+func fireHooks(entry *Entry) {
+	hooksMu.Lock()
+	regs := hooks
+	hooksMu.Unlock()
+	for _, reg := range regs {
+		if reg.levels != nil && !reg.levels[entry.Level] {
+			continue
+		}
+		if err := reg.hook.Fire(entry); err != nil {
+			// A failing hook is a problem with the side channel, not with
+			// logging itself, so it must never suppress the original entry.
+			fmt.Fprintf(os.Stderr, "golog: hook failed: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package golog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level identifies the severity of a log entry. Levels are ordered from
+// least to most severe; a Logger configured at a given Level also logs
+// everything at least as severe as it.
+type Level int32
+
+const (
+	Trace Level = iota
+	Debug
+	Info
+	Warn
+	Error
+	Fatal
+)
+
+// String returns the textual severity used as the prefix of each log line
+// (e.g. "DEBUG", "WARN").
+func (lv Level) String() string {
+	switch lv {
+	case Trace:
+		return "TRACE"
+	case Debug:
+		return "DEBUG"
+	case Info:
+		return "INFO"
+	case Warn:
+		return "WARN"
+	case Error:
+		return "ERROR"
+	case Fatal:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel parses a level name case-insensitively (e.g. "debug", "WARN"),
+// as accepted by LevelHandler.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "TRACE":
+		return Trace, nil
+	case "DEBUG":
+		return Debug, nil
+	case "INFO":
+		return Info, nil
+	case "WARN", "WARNING":
+		return Warn, nil
+	case "ERROR":
+		return Error, nil
+	case "FATAL":
+		return Fatal, nil
+	default:
+		return 0, fmt.Errorf("unrecognized level %q", s)
+	}
+}
@@ -0,0 +1,32 @@
+package golog
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLevel(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+	l := LoggerFor("levelprefix")
+
+	SetLevel("levelprefix", Warn)
+	l.Info("should be suppressed")
+	assert.Equal(t, "", string(out.Bytes()))
+
+	l.Warn("should show up")
+	assert.Regexp(t, `WARN levelprefix: level_test.go:\d+ should show up`, string(out.Bytes()))
+}
+
+func TestSetLevelGlob(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+
+	SetLevelGlob("http.*", Error)
+	l := LoggerFor("http.server")
+	l.Warn("should be suppressed by the glob rule")
+	assert.Equal(t, "", string(out.Bytes()))
+	assert.Equal(t, Error, GetLevel("http.server"))
+}
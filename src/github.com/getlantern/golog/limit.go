@@ -0,0 +1,104 @@
+package golog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// limiter decides, per call-site program counter, whether the current call
+// should be let through. When it suppresses a call, it accumulates a count
+// that gets handed to onSuppressed the next time a call at that site is
+// allowed through, so operators see a single summary line rather than
+// nothing at all.
+type limiter interface {
+	allow(pc uintptr, onSuppressed func(suppressed int)) bool
+}
+
+// Sample returns a child Logger that, for each distinct call site, only
+// emits every nth call. The rest are counted and periodically surfaced as a
+// single "N similar messages suppressed" line.
+func (l *logger) Sample(n int) Logger {
+	child := l.clone()
+	child.limiter = &sampler{n: int64(n)}
+	return child
+}
+
+// RateLimit returns a child Logger that, for each distinct call site, emits
+// at most burst calls every per, using a token bucket refilled lazily (on
+// each attempt) from a low-resolution clock. Calls beyond the burst are
+// counted and periodically surfaced as a single "N similar messages
+// suppressed" line.
+func (l *logger) RateLimit(per time.Duration, burst int) Logger {
+	ensureCoarseClock()
+	child := l.clone()
+	child.limiter = &rateLimiter{per: per, burst: int64(burst)}
+	return child
+}
+
+// callSite holds the mutable state a limiter tracks for one PC.
+type callSite struct {
+	calls      int64
+	suppressed int64
+	tokens     int64
+	lastFill   int64
+}
+
+func callSiteFor(counters *sync.Map, pc uintptr, initTokens int64) *callSite {
+	if c, ok := counters.Load(pc); ok {
+		return c.(*callSite)
+	}
+	c, _ := counters.LoadOrStore(pc, &callSite{tokens: initTokens, lastFill: atomic.LoadInt64(&coarseNow)})
+	return c.(*callSite)
+}
+
+// sampler lets through every nth call at a given call site.
+type sampler struct {
+	n        int64
+	counters sync.Map // uintptr -> *callSite
+}
+
+func (s *sampler) allow(pc uintptr, onSuppressed func(int)) bool {
+	if s.n <= 1 {
+		return true
+	}
+	c := callSiteFor(&s.counters, pc, 0)
+	if atomic.AddInt64(&c.calls, 1)%s.n == 0 {
+		if suppressed := atomic.SwapInt64(&c.suppressed, 0); suppressed > 0 {
+			onSuppressed(int(suppressed))
+		}
+		return true
+	}
+	atomic.AddInt64(&c.suppressed, 1)
+	return false
+}
+
+// rateLimiter is a per-call-site token bucket.
+type rateLimiter struct {
+	per      time.Duration
+	burst    int64
+	counters sync.Map // uintptr -> *callSite
+}
+
+func (r *rateLimiter) allow(pc uintptr, onSuppressed func(int)) bool {
+	c := callSiteFor(&r.counters, pc, r.burst)
+
+	now := atomic.LoadInt64(&coarseNow)
+	last := atomic.LoadInt64(&c.lastFill)
+	if elapsed := time.Duration(now - last); elapsed >= r.per && atomic.CompareAndSwapInt64(&c.lastFill, last, now) {
+		refilled := atomic.AddInt64(&c.tokens, int64(elapsed/r.per)*r.burst)
+		if refilled > r.burst {
+			atomic.StoreInt64(&c.tokens, r.burst)
+		}
+	}
+
+	if atomic.AddInt64(&c.tokens, -1) >= 0 {
+		if suppressed := atomic.SwapInt64(&c.suppressed, 0); suppressed > 0 {
+			onSuppressed(int(suppressed))
+		}
+		return true
+	}
+	atomic.AddInt64(&c.tokens, 1) // give back the token we speculatively took
+	atomic.AddInt64(&c.suppressed, 1)
+	return false
+}
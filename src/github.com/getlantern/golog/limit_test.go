@@ -0,0 +1,49 @@
+package golog
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSample(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+	l := LoggerFor("sampleprefix").Sample(3)
+
+	for i := 0; i < 7; i++ {
+		l.Debug("tick")
+	}
+
+	lines := countLines(out.Bytes())
+	// calls 3 and 6 get through; each is preceded by a "suppressed" summary
+	// for the 2 calls skipped since the last one that got through.
+	assert.Equal(t, 4, lines)
+}
+
+func TestRateLimit(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+	l := LoggerFor("ratelimitprefix").RateLimit(time.Hour, 2)
+
+	for i := 0; i < 5; i++ {
+		l.Debug("tick")
+	}
+
+	lines := countLines(out.Bytes())
+	// only the initial burst of 2 gets through; the rest are suppressed and
+	// have no further call to attach a summary line to within this test.
+	assert.Equal(t, 2, lines)
+}
+
+func countLines(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c == '\n' {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,51 @@
+package golog
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextRoundTrip(t *testing.T) {
+	l := LoggerFor("ctxprefix").WithRequestID("abc123")
+	ctx := WithContext(context.Background(), l)
+	assert.Equal(t, l, FromContext(ctx))
+	assert.Equal(t, defaultLogger, FromContext(context.Background()))
+}
+
+func TestLoggerWithContextMergesStashedFields(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+
+	stashed := LoggerFor("ctxprefix").WithRequestID("abc123")
+	ctx := WithContext(context.Background(), stashed)
+
+	l := LoggerFor("otherprefix").WithField("n", 5).WithContext(ctx)
+	l.Debug("hello")
+
+	assert.Regexp(t, `DEBUG otherprefix: logctx_test.go:\d+ hello n=5 request_id=abc123`, string(out.Bytes()))
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(ioutil.Discard, out)
+	SetLevel("http.access", Info)
+
+	var sawLogger Logger
+	handler := HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawLogger = FromContext(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.NotEqual(t, defaultLogger, sawLogger, "handler should see a request-scoped Logger")
+	assert.NotEmpty(t, rec.Header().Get(requestIDHeader))
+	assert.Regexp(t, `INFO http\.access: logctx\.go:\d+ access .*status=418`, string(out.Bytes()))
+}
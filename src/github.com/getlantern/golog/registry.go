@@ -0,0 +1,107 @@
+package golog
+
+import (
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// unset marks a registry entry that has never had SetLevel/SetLevelGlob
+// applied to it, so that it keeps tracking the TRACE environment variable
+// live rather than freezing the level as of its first LoggerFor call.
+const unset int32 = -1
+
+// registry holds the current Level for every prefix a Logger has been
+// created for, so that SetLevel/SetLevelGlob can reconfigure already-running
+// Loggers in place: each Logger holds a pointer into this map and checks it
+// with a cheap atomic load on every call.
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*int32{}
+	globs      []globRule
+)
+
+type globRule struct {
+	pattern string
+	level   int32
+}
+
+// defaultLevel is the level a newly-registered prefix starts at absent any
+// SetLevel/SetLevelGlob rule: Trace if TRACE=true, Debug otherwise. This
+// matches golog's original TRACE-env behavior.
+func defaultLevel() Level {
+	if strings.TrimSpace(strings.ToLower(os.Getenv("TRACE"))) == "true" {
+		return Trace
+	}
+	return Debug
+}
+
+// registerPrefix returns the shared level pointer for prefix, creating it
+// (seeded from any matching glob rule, or left unset to track the TRACE env
+// var) the first time prefix is seen.
+func registerPrefix(prefix string) *int32 {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if p, ok := registry[prefix]; ok {
+		return p
+	}
+	v := unset
+	for _, rule := range globs {
+		if matched, _ := path.Match(rule.pattern, prefix); matched {
+			v = rule.level
+		}
+	}
+	p := &v
+	registry[prefix] = p
+	return p
+}
+
+// effectiveLevel resolves the Level stored behind p, falling back to the
+// live default (TRACE env var) if no explicit level has been set.
+func effectiveLevel(p *int32) Level {
+	v := atomic.LoadInt32(p)
+	if v == unset {
+		return defaultLevel()
+	}
+	return Level(v)
+}
+
+// SetLevel sets the minimum Level that will be logged by the Logger(s) for
+// the given prefix, taking effect immediately on any Logger already obtained
+// via LoggerFor.
+func SetLevel(prefix string, level Level) {
+	atomic.StoreInt32(registerPrefix(prefix), int32(level))
+}
+
+// SetLevelGlob sets the minimum Level for every prefix matching pattern (as
+// interpreted by path.Match, e.g. "http.*"), both for prefixes already
+// registered and for any seen later.
+func SetLevelGlob(pattern string, level Level) {
+	registryMu.Lock()
+	globs = append(globs, globRule{pattern: pattern, level: int32(level)})
+	for name, p := range registry {
+		if matched, _ := path.Match(pattern, name); matched {
+			atomic.StoreInt32(p, int32(level))
+		}
+	}
+	registryMu.Unlock()
+}
+
+// GetLevel returns the current effective Level for prefix.
+func GetLevel(prefix string) Level {
+	return effectiveLevel(registerPrefix(prefix))
+}
+
+// Levels returns a snapshot of every prefix registered so far (via
+// LoggerFor, SetLevel or GetLevel) and its current effective Level.
+func Levels() map[string]Level {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	out := make(map[string]Level, len(registry))
+	for name, p := range registry {
+		out[name] = effectiveLevel(p)
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package golog
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Frame describes a single stack frame captured by CaptureError or
+// ErrorWithStack, exposed directly (rather than as pre-formatted text) so
+// hooks can forward it to Sentry-like sinks without re-parsing text.
+type Frame struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function"`
+}
+
+// String renders a Frame the way it appears in a text-formatted stack
+// trace.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Function)
+}
+
+// StackTracer is implemented by errors that carry a captured stack trace,
+// such as ones produced by CaptureError or Logger.ErrorWithStack. Wrapping
+// such an error with fmt.Errorf("%w", err) preserves the trace, since
+// asStackTracer (used by Error/Errorf/WithError) walks the Unwrap chain to
+// find it.
+type StackTracer interface {
+	error
+	StackTrace() []Frame
+}
+
+type withStack struct {
+	err    error
+	frames []Frame
+}
+
+func (w *withStack) Error() string       { return w.err.Error() }
+func (w *withStack) Unwrap() error       { return w.err }
+func (w *withStack) StackTrace() []Frame { return w.frames }
+
+// CaptureError wraps err with a stack trace captured at the point of this
+// call, skipping golog's own frames. If err is nil, CaptureError returns
+// nil. If err already carries a captured stack trace (directly or via its
+// Unwrap chain), it's returned unchanged rather than wrapped twice.
+func CaptureError(err error) error {
+	return captureAt(err, 4)
+}
+
+// ErrorWithStack logs err as an Error entry with a captured (or, if err
+// already has one, reused) stack trace, and returns the stack-carrying
+// error.
+func (l *logger) ErrorWithStack(err error) error {
+	// captureAt is called directly here (rather than via CaptureError) so
+	// its skip count lands on ErrorWithStack's caller rather than on
+	// ErrorWithStack itself.
+	wrapped := captureAt(err, 4)
+	if wrapped == nil {
+		return nil
+	}
+	l.dispatch(Error, 2, wrapped.Error(), framesOf(wrapped))
+	return wrapped
+}
+
+// captureAt wraps err with a stack trace, unless err already carries one.
+// skip is passed to runtime.Callers unchanged, so 4 starts the trace at the
+// caller of captureAt's caller.
+func captureAt(err error, skip int) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asStackTracer(err); ok {
+		return err
+	}
+	return &withStack{err: err, frames: captureFrames(skip)}
+}
+
+// asStackTracer walks err's Unwrap chain looking for a StackTracer.
+func asStackTracer(err error) (StackTracer, bool) {
+	for err != nil {
+		if st, ok := err.(StackTracer); ok {
+			return st, true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return nil, false
+		}
+		err = u.Unwrap()
+	}
+	return nil, false
+}
+
+// framesOf returns the stack frames carried by err, if any.
+func framesOf(err error) []Frame {
+	if st, ok := asStackTracer(err); ok {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+func captureFrames(skip int) []Frame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	out := make([]Frame, 0, n)
+	for {
+		f, more := frames.Next()
+		out = append(out, Frame{File: f.File, Line: f.Line, Function: f.Function})
+		if !more {
+			break
+		}
+	}
+	return out
+}
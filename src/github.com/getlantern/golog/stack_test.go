@@ -0,0 +1,77 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureErrorSurvivesWrapping(t *testing.T) {
+	captured := CaptureError(errors.New("boom"))
+	wrapped := fmt.Errorf("request failed: %w", captured)
+
+	st, ok := asStackTracer(wrapped)
+	assert.True(t, ok, "wrapped error should still expose a StackTracer")
+	assert.NotEmpty(t, st.StackTrace())
+
+	// Capturing an already-captured error doesn't wrap it twice.
+	assert.Same(t, captured, CaptureError(captured))
+}
+
+func TestErrorWithStack(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(out, ioutil.Discard)
+	l := LoggerFor("stackprefix")
+
+	l.ErrorWithStack(errors.New("kaboom"))
+
+	assert.Regexp(t, `ERROR stackprefix: stack_test\.go:\d+ kaboom\n\t.+\n`, string(out.Bytes()))
+}
+
+func TestWithErrorIncludesStack(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(out, ioutil.Discard)
+	l := LoggerFor("stackprefix").WithError(CaptureError(errors.New("kaboom")))
+
+	l.Error("failed")
+
+	assert.Regexp(t, `ERROR stackprefix: stack_test\.go:\d+ failed error=kaboom\n\t.+\n`, string(out.Bytes()))
+}
+
+// callCaptureError exists only so the test below has a call site distinct
+// from CaptureError's own definition to check frame0 against.
+func callCaptureError() error {
+	return CaptureError(errors.New("boom"))
+}
+
+func TestCaptureErrorFrame0IsCallSite(t *testing.T) {
+	st, ok := asStackTracer(callCaptureError())
+	assert.True(t, ok)
+	frames := st.StackTrace()
+	assert.NotEmpty(t, frames)
+	assert.Equal(t, "stack_test.go", filepath.Base(frames[0].File), "frame0 should be the caller, not CaptureError itself")
+	assert.NotContains(t, frames[0].Function, ".CaptureError")
+}
+
+// callErrorWithStack exists only so the test below has a call site distinct
+// from ErrorWithStack's own definition to check frame0 against.
+func callErrorWithStack(l Logger) error {
+	return l.ErrorWithStack(errors.New("kaboom"))
+}
+
+func TestErrorWithStackFrame0IsCallSite(t *testing.T) {
+	out := newBuffer()
+	SetOutputs(out, ioutil.Discard)
+	l := LoggerFor("stackprefix")
+
+	st, ok := asStackTracer(callErrorWithStack(l))
+	assert.True(t, ok)
+	frames := st.StackTrace()
+	assert.NotEmpty(t, frames)
+	assert.Equal(t, "stack_test.go", filepath.Base(frames[0].File), "frame0 should be the caller, not ErrorWithStack itself")
+	assert.NotContains(t, frames[0].Function, ".ErrorWithStack")
+}